@@ -0,0 +1,104 @@
+package lorc
+
+import "strconv"
+
+// Value is implemented by every node the parser can produce: Object,
+// Array, String, Number, Bool, and Null.
+type Value interface {
+	isValue()
+}
+
+// Entry is a single key/value pair within an Object, in the order it
+// was parsed.
+type Entry struct {
+	Key   string
+	Value Value
+}
+
+// Object is a JSON object that preserves the source order of its keys.
+// index maps a key to its position in entries for O(1) lookup.
+type Object struct {
+	entries []Entry
+	index   map[string]int
+}
+
+func (*Object) isValue() {}
+
+// Set appends a key/value pair, or overwrites the value of an existing
+// entry in place if the key is already present.
+func (o *Object) Set(key string, value Value) {
+	if i, ok := o.index[key]; ok {
+		o.entries[i].Value = value
+		return
+	}
+	if o.index == nil {
+		o.index = make(map[string]int)
+	}
+	o.index[key] = len(o.entries)
+	o.entries = append(o.entries, Entry{Key: key, Value: value})
+}
+
+// Get returns the value stored under key and whether it was present.
+func (o *Object) Get(key string) (Value, bool) {
+	if i, ok := o.index[key]; ok {
+		return o.entries[i].Value, true
+	}
+	return nil, false
+}
+
+// Keys returns the object's keys in insertion order.
+func (o *Object) Keys() []string {
+	keys := make([]string, len(o.entries))
+	for i, e := range o.entries {
+		keys[i] = e.Key
+	}
+	return keys
+}
+
+// Entries returns the object's key/value pairs in insertion order.
+func (o *Object) Entries() []Entry {
+	return o.entries
+}
+
+// Len returns the number of entries in the object.
+func (o *Object) Len() int {
+	return len(o.entries)
+}
+
+// Array is an ordered list of JSON values.
+type Array struct {
+	Elements []Value
+}
+
+func (*Array) isValue() {}
+
+// String is a JSON string value.
+type String string
+
+func (String) isValue() {}
+
+// Number is a JSON number, held as its original literal so that
+// callers can decide how to parse it (int64, uint64, or float64).
+type Number string
+
+func (Number) isValue() {}
+
+// Float64 parses the number as a float64.
+func (n Number) Float64() (float64, error) {
+	return strconv.ParseFloat(string(n), 64)
+}
+
+// Int64 parses the number as an int64.
+func (n Number) Int64() (int64, error) {
+	return strconv.ParseInt(string(n), 10, 64)
+}
+
+// Bool is a JSON boolean value.
+type Bool bool
+
+func (Bool) isValue() {}
+
+// Null is the JSON null value.
+type Null struct{}
+
+func (Null) isValue() {}
@@ -0,0 +1,40 @@
+// Command lorc validates a JSON document read from a file argument or
+// stdin.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	lorc "github.com/Marbh56/Lorc"
+)
+
+func main() {
+	var input io.Reader = os.Stdin
+
+	if len(os.Args) > 1 {
+		file, err := os.Open(os.Args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	data, err := io.ReadAll(input)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+		os.Exit(1)
+	}
+
+	var v any
+	if err := lorc.Unmarshal(data, &v); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid JSON: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Valid JSON")
+	os.Exit(0)
+}
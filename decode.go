@@ -0,0 +1,328 @@
+package lorc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Unmarshaler is implemented by types that can decode a JSON
+// representation of themselves.
+type Unmarshaler interface {
+	UnmarshalJSON([]byte) error
+}
+
+var (
+	unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+	numberType      = reflect.TypeOf(Number(""))
+)
+
+// Unmarshal parses data as JSON and stores the result in the value
+// pointed to by v.
+func Unmarshal(data []byte, v any) error {
+	value, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("lorc: Unmarshal requires a non-nil pointer, got %T", v)
+	}
+	return decodeValue(value, rv.Elem())
+}
+
+func decodeValue(val Value, rv reflect.Value) error {
+	for rv.Kind() == reflect.Pointer {
+		if rv.Type().Implements(unmarshalerType) {
+			if rv.IsNil() {
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			return callUnmarshaler(rv.Interface().(Unmarshaler), val)
+		}
+		if _, isNull := val.(Null); isNull {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.CanAddr() && rv.Addr().Type().Implements(unmarshalerType) {
+		return callUnmarshaler(rv.Addr().Interface().(Unmarshaler), val)
+	}
+
+	if _, isNull := val.(Null); isNull {
+		switch rv.Kind() {
+		case reflect.Map, reflect.Slice, reflect.Interface:
+			rv.Set(reflect.Zero(rv.Type()))
+		}
+		return nil
+	}
+
+	if rv.Type() == numberType {
+		n, ok := val.(Number)
+		if !ok {
+			return fmt.Errorf("lorc: cannot decode %s into lorc.Number", describeValue(val))
+		}
+		rv.SetString(string(n))
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Interface:
+		if rv.NumMethod() != 0 {
+			return fmt.Errorf("lorc: cannot decode into interface %s", rv.Type())
+		}
+		native, err := toNative(val)
+		if err != nil {
+			return err
+		}
+		if native == nil {
+			rv.Set(reflect.Zero(rv.Type()))
+		} else {
+			rv.Set(reflect.ValueOf(native))
+		}
+		return nil
+
+	case reflect.Struct:
+		return decodeStruct(val, rv)
+
+	case reflect.Map:
+		return decodeMap(val, rv)
+
+	case reflect.Slice, reflect.Array:
+		return decodeSlice(val, rv)
+
+	case reflect.String:
+		s, ok := val.(String)
+		if !ok {
+			return fmt.Errorf("lorc: cannot decode %s into string", describeValue(val))
+		}
+		rv.SetString(string(s))
+		return nil
+
+	case reflect.Bool:
+		b, ok := val.(Bool)
+		if !ok {
+			return fmt.Errorf("lorc: cannot decode %s into bool", describeValue(val))
+		}
+		rv.SetBool(bool(b))
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := val.(Number)
+		if !ok {
+			return fmt.Errorf("lorc: cannot decode %s into %s", describeValue(val), rv.Type())
+		}
+		i, err := n.Int64()
+		if err != nil || rv.OverflowInt(i) {
+			return fmt.Errorf("lorc: number %q overflows %s", string(n), rv.Type())
+		}
+		rv.SetInt(i)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, ok := val.(Number)
+		if !ok {
+			return fmt.Errorf("lorc: cannot decode %s into %s", describeValue(val), rv.Type())
+		}
+		u, err := strconv.ParseUint(string(n), 10, 64)
+		if err != nil || rv.OverflowUint(u) {
+			return fmt.Errorf("lorc: number %q overflows %s", string(n), rv.Type())
+		}
+		rv.SetUint(u)
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		n, ok := val.(Number)
+		if !ok {
+			return fmt.Errorf("lorc: cannot decode %s into %s", describeValue(val), rv.Type())
+		}
+		f, err := n.Float64()
+		if err != nil || rv.OverflowFloat(f) {
+			return fmt.Errorf("lorc: number %q overflows %s", string(n), rv.Type())
+		}
+		rv.SetFloat(f)
+		return nil
+
+	default:
+		return fmt.Errorf("lorc: unsupported type %s", rv.Type())
+	}
+}
+
+func callUnmarshaler(u Unmarshaler, val Value) error {
+	raw, err := Marshal(val)
+	if err != nil {
+		return err
+	}
+	return u.UnmarshalJSON(raw)
+}
+
+func decodeStruct(val Value, rv reflect.Value) error {
+	obj, ok := val.(*Object)
+	if !ok {
+		return fmt.Errorf("lorc: cannot decode %s into %s", describeValue(val), rv.Type())
+	}
+	t := rv.Type()
+	for _, entry := range obj.Entries() {
+		idx := findField(t, entry.Key)
+		if idx < 0 {
+			continue
+		}
+		if err := decodeValue(entry.Value, rv.Field(idx)); err != nil {
+			return fmt.Errorf("field %q: %w", entry.Key, err)
+		}
+	}
+	return nil
+}
+
+// findField returns the index of the struct field matching key,
+// preferring an exact tag/name match and falling back to a
+// case-insensitive one.
+func findField(t reflect.Type, key string) int {
+	fallback := -1
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := parseFieldTag(sf)
+		if tag.skip {
+			continue
+		}
+		if tag.name == key {
+			return i
+		}
+		if fallback < 0 && strings.EqualFold(tag.name, key) {
+			fallback = i
+		}
+	}
+	return fallback
+}
+
+func decodeMap(val Value, rv reflect.Value) error {
+	obj, ok := val.(*Object)
+	if !ok {
+		return fmt.Errorf("lorc: cannot decode %s into %s", describeValue(val), rv.Type())
+	}
+	if rv.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("lorc: unsupported map key type %s", rv.Type().Key())
+	}
+	if rv.IsNil() {
+		rv.Set(reflect.MakeMapWithSize(rv.Type(), obj.Len()))
+	}
+	elemType := rv.Type().Elem()
+	keyType := rv.Type().Key()
+	for _, entry := range obj.Entries() {
+		ev := reflect.New(elemType).Elem()
+		if err := decodeValue(entry.Value, ev); err != nil {
+			return fmt.Errorf("key %q: %w", entry.Key, err)
+		}
+		rv.SetMapIndex(reflect.ValueOf(entry.Key).Convert(keyType), ev)
+	}
+	return nil
+}
+
+func decodeSlice(val Value, rv reflect.Value) error {
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+		if s, ok := val.(String); ok {
+			data, err := base64.StdEncoding.DecodeString(string(s))
+			if err != nil {
+				return fmt.Errorf("lorc: invalid base64 string: %w", err)
+			}
+			rv.SetBytes(data)
+			return nil
+		}
+	}
+
+	arr, ok := val.(*Array)
+	if !ok {
+		return fmt.Errorf("lorc: cannot decode %s into %s", describeValue(val), rv.Type())
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice:
+		out := reflect.MakeSlice(rv.Type(), len(arr.Elements), len(arr.Elements))
+		for i, elem := range arr.Elements {
+			if err := decodeValue(elem, out.Index(i)); err != nil {
+				return fmt.Errorf("index %d: %w", i, err)
+			}
+		}
+		rv.Set(out)
+	case reflect.Array:
+		for i, elem := range arr.Elements {
+			if i >= rv.Len() {
+				break
+			}
+			if err := decodeValue(elem, rv.Index(i)); err != nil {
+				return fmt.Errorf("index %d: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// toNative converts val into the plain Go types (map[string]any,
+// []any, string, lorc.Number, bool, nil) used when decoding into
+// `any`. Numbers are retained as their original literal (lorc.Number)
+// rather than converted to float64, so round-tripping through Marshal
+// preserves precision and shape for values like large int64 IDs.
+func toNative(val Value) (any, error) {
+	switch v := val.(type) {
+	case Null:
+		return nil, nil
+	case Bool:
+		return bool(v), nil
+	case String:
+		return string(v), nil
+	case Number:
+		return v, nil
+	case *Array:
+		out := make([]any, len(v.Elements))
+		for i, elem := range v.Elements {
+			n, err := toNative(elem)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = n
+		}
+		return out, nil
+	case *Object:
+		out := make(map[string]any, v.Len())
+		for _, entry := range v.Entries() {
+			n, err := toNative(entry.Value)
+			if err != nil {
+				return nil, err
+			}
+			out[entry.Key] = n
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("lorc: unsupported value type %T", val)
+	}
+}
+
+func describeValue(val Value) string {
+	switch val.(type) {
+	case Null:
+		return "null"
+	case Bool:
+		return "bool"
+	case String:
+		return "string"
+	case Number:
+		return "number"
+	case *Array:
+		return "array"
+	case *Object:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", val)
+	}
+}
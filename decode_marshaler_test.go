@@ -0,0 +1,79 @@
+package lorc
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// hexID round-trips as a quoted "0x"-prefixed hex string, exercising
+// the Marshaler/Unmarshaler interface pair instead of the default
+// reflection-based encoding.
+type hexID uint32
+
+func (h hexID) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote("0x" + strconv.FormatUint(uint64(h), 16))), nil
+}
+
+func (h *hexID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := Unmarshal(data, &s); err != nil {
+		return err
+	}
+	n, err := strconv.ParseUint(strings.TrimPrefix(s, "0x"), 16, 32)
+	if err != nil {
+		return err
+	}
+	*h = hexID(n)
+	return nil
+}
+
+type widget struct {
+	Name string `json:"name"`
+	ID   hexID  `json:"id"`
+	Alt  *hexID `json:"alt,omitempty"`
+}
+
+func TestMarshalerUnmarshalerRoundTrip(t *testing.T) {
+	var w widget
+	if err := Unmarshal([]byte(`{"name":"gizmo","id":"0x2a","alt":"0xff"}`), &w); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if w.ID != 42 {
+		t.Errorf("ID = %d, want 42", w.ID)
+	}
+	if w.Alt == nil || *w.Alt != 255 {
+		t.Fatalf("Alt = %v, want pointer to 255", w.Alt)
+	}
+
+	out, err := Marshal(w)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	want := `{"name":"gizmo","id":"0x2a","alt":"0xff"}`
+	if string(out) != want {
+		t.Errorf("Marshal = %s, want %s", out, want)
+	}
+}
+
+func TestUnmarshalerAllocatesNilPointerField(t *testing.T) {
+	var w widget
+	if err := Unmarshal([]byte(`{"alt":"0x1"}`), &w); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if w.Alt == nil || *w.Alt != 1 {
+		t.Fatalf("Alt = %v, want pointer to 1", w.Alt)
+	}
+}
+
+func TestMarshalerOmitsNilPointerField(t *testing.T) {
+	w := widget{Name: "gizmo"}
+	out, err := Marshal(w)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	want := `{"name":"gizmo","id":"0x0"}`
+	if string(out) != want {
+		t.Errorf("Marshal = %s, want %s", out, want)
+	}
+}
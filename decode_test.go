@@ -0,0 +1,101 @@
+package lorc
+
+import (
+	"testing"
+)
+
+type person struct {
+	Name    string   `json:"name"`
+	Age     int      `json:"age,omitempty"`
+	Emails  []string `json:"emails"`
+	private string
+}
+
+func TestUnmarshalStructRoundTrip(t *testing.T) {
+	var p person
+	if err := Unmarshal([]byte(`{"name":"Ada","age":36,"emails":["ada@example.com"]}`), &p); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if p.Name != "Ada" || p.Age != 36 || len(p.Emails) != 1 || p.Emails[0] != "ada@example.com" {
+		t.Fatalf("Unmarshal produced %+v", p)
+	}
+
+	out, err := Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	want := `{"name":"Ada","age":36,"emails":["ada@example.com"]}`
+	if string(out) != want {
+		t.Errorf("Marshal = %s, want %s", out, want)
+	}
+}
+
+func TestMarshalOmitemptyOmitsZeroValue(t *testing.T) {
+	p := person{Name: "Bo"}
+	out, err := Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	want := `{"name":"Bo","emails":null}`
+	if string(out) != want {
+		t.Errorf("Marshal = %s, want %s", out, want)
+	}
+}
+
+func TestUnmarshalCaseInsensitiveFallback(t *testing.T) {
+	var p person
+	if err := Unmarshal([]byte(`{"NAME":"Grace"}`), &p); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if p.Name != "Grace" {
+		t.Errorf("Name = %q, want Grace", p.Name)
+	}
+}
+
+func TestUnmarshalIntOverflow(t *testing.T) {
+	var v int8
+	err := Unmarshal([]byte(`1000`), &v)
+	if err == nil {
+		t.Fatalf("expected overflow error, got nil")
+	}
+}
+
+func TestUnmarshalIntoAnyPreservesNumberLiteral(t *testing.T) {
+	var v any
+	if err := Unmarshal([]byte(`{"id":9007199254740993}`), &v); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	out, err := Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	want := `{"id":9007199254740993}`
+	if string(out) != want {
+		t.Errorf("round-trip = %s, want %s", out, want)
+	}
+}
+
+func TestUnmarshalMapAndSlice(t *testing.T) {
+	m := map[string]int{}
+	if err := Unmarshal([]byte(`{"a":1,"b":2}`), &m); err != nil {
+		t.Fatalf("Unmarshal map: %v", err)
+	}
+	if m["a"] != 1 || m["b"] != 2 {
+		t.Fatalf("Unmarshal produced %v", m)
+	}
+
+	var s []int
+	if err := Unmarshal([]byte(`[1,2,3]`), &s); err != nil {
+		t.Fatalf("Unmarshal slice: %v", err)
+	}
+	if len(s) != 3 || s[0] != 1 || s[2] != 3 {
+		t.Fatalf("Unmarshal produced %v", s)
+	}
+}
+
+func TestUnmarshalTypeMismatchError(t *testing.T) {
+	var n int
+	if err := Unmarshal([]byte(`"not a number"`), &n); err == nil {
+		t.Errorf("expected error decoding string into int, got nil")
+	}
+}
@@ -0,0 +1,438 @@
+package lorc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Marshaler is implemented by types that can encode a JSON
+// representation of themselves.
+type Marshaler interface {
+	MarshalJSON() ([]byte, error)
+}
+
+var marshalerType = reflect.TypeOf((*Marshaler)(nil)).Elem()
+
+// Marshal returns the JSON encoding of v.
+func Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	enc.SetEscapeHTML(true)
+	if err := enc.encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalIndent is like Marshal but formats the output with prefix
+// and indent as Encoder.SetIndent does, one array or object entry per
+// line.
+func MarshalIndent(v any, prefix, indent string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	enc.SetEscapeHTML(true)
+	enc.SetIndent(prefix, indent)
+	if err := enc.encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Encoder writes JSON values to an output stream.
+type Encoder struct {
+	w          *bufio.Writer
+	escapeHTML bool
+	prefix     string
+	indent     string
+}
+
+// NewEncoder returns an Encoder that writes to w. HTML-safe escaping
+// is on by default, matching encoding/json.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w), escapeHTML: true}
+}
+
+// SetEscapeHTML controls whether '<', '>', '&', U+2028, and U+2029
+// are escaped inside JSON strings, so the output can be safely
+// embedded in HTML.
+func (e *Encoder) SetEscapeHTML(on bool) {
+	e.escapeHTML = on
+}
+
+// SetIndent instructs the Encoder to format each subsequent Encode
+// call with the given prefix and per-level indent, one array or
+// object entry per line. An empty indent reverts to compact output.
+func (e *Encoder) SetIndent(prefix, indent string) {
+	e.prefix = prefix
+	e.indent = indent
+}
+
+// Encode writes the JSON encoding of v, followed by a newline.
+func (e *Encoder) Encode(v any) error {
+	if err := e.encode(v); err != nil {
+		return err
+	}
+	if err := e.w.WriteByte('\n'); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+func (e *Encoder) encode(v any) error {
+	if err := e.encodeReflect(reflect.ValueOf(v), 0); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+func (e *Encoder) newline(depth int) error {
+	if e.indent == "" {
+		return nil
+	}
+	if _, err := e.w.WriteString("\n" + e.prefix); err != nil {
+		return err
+	}
+	for i := 0; i < depth; i++ {
+		if _, err := e.w.WriteString(e.indent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeReflect encodes rv, dispatching to Marshaler, the lorc.Value
+// AST types, or plain reflection as appropriate.
+func (e *Encoder) encodeReflect(rv reflect.Value, depth int) error {
+	if !rv.IsValid() {
+		return e.writeRaw("null")
+	}
+
+	if rv.CanInterface() {
+		if lv, ok := rv.Interface().(Value); ok {
+			return e.encodeLorcValue(lv, depth)
+		}
+		if m, ok := rv.Interface().(Marshaler); ok {
+			return e.encodeMarshaler(m, depth)
+		}
+	}
+	if rv.Kind() != reflect.Pointer && rv.CanAddr() && rv.Addr().CanInterface() {
+		if m, ok := rv.Addr().Interface().(Marshaler); ok {
+			return e.encodeMarshaler(m, depth)
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return e.writeRaw("null")
+		}
+		return e.encodeReflect(rv.Elem(), depth)
+	case reflect.Interface:
+		if rv.IsNil() {
+			return e.writeRaw("null")
+		}
+		return e.encodeReflect(rv.Elem(), depth)
+	case reflect.Bool:
+		return e.writeRaw(strconv.FormatBool(rv.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return e.writeRaw(strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return e.writeRaw(strconv.FormatUint(rv.Uint(), 10))
+	case reflect.Float32:
+		return e.encodeFloat(rv.Float(), 32)
+	case reflect.Float64:
+		return e.encodeFloat(rv.Float(), 64)
+	case reflect.String:
+		return e.writeString(rv.String())
+	case reflect.Slice:
+		if rv.IsNil() {
+			return e.writeRaw("null")
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return e.writeString(base64.StdEncoding.EncodeToString(rv.Bytes()))
+		}
+		return e.encodeArray(rv, depth)
+	case reflect.Array:
+		return e.encodeArray(rv, depth)
+	case reflect.Map:
+		return e.encodeMap(rv, depth)
+	case reflect.Struct:
+		return e.encodeStruct(rv, depth)
+	default:
+		return fmt.Errorf("lorc: unsupported type %s", rv.Type())
+	}
+}
+
+func (e *Encoder) encodeMarshaler(m Marshaler, depth int) error {
+	raw, err := m.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	v, err := Parse(bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("lorc: invalid JSON from %T.MarshalJSON: %w", m, err)
+	}
+	return e.encodeLorcValue(v, depth)
+}
+
+func (e *Encoder) encodeFloat(f float64, bitSize int) error {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Errorf("lorc: unsupported value: %v", f)
+	}
+	return e.writeRaw(strconv.FormatFloat(f, 'g', -1, bitSize))
+}
+
+func (e *Encoder) encodeArray(rv reflect.Value, depth int) error {
+	if err := e.writeRaw("["); err != nil {
+		return err
+	}
+	n := rv.Len()
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			if err := e.writeRaw(","); err != nil {
+				return err
+			}
+		}
+		if err := e.newline(depth + 1); err != nil {
+			return err
+		}
+		if err := e.encodeReflect(rv.Index(i), depth+1); err != nil {
+			return err
+		}
+	}
+	if n > 0 {
+		if err := e.newline(depth); err != nil {
+			return err
+		}
+	}
+	return e.writeRaw("]")
+}
+
+func (e *Encoder) encodeMap(rv reflect.Value, depth int) error {
+	if rv.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("lorc: unsupported map key type %s", rv.Type().Key())
+	}
+	if rv.IsNil() {
+		return e.writeRaw("null")
+	}
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	if err := e.writeRaw("{"); err != nil {
+		return err
+	}
+	for i, k := range keys {
+		if i > 0 {
+			if err := e.writeRaw(","); err != nil {
+				return err
+			}
+		}
+		if err := e.newline(depth + 1); err != nil {
+			return err
+		}
+		if err := e.writeString(k.String()); err != nil {
+			return err
+		}
+		if err := e.writeRaw(":"); err != nil {
+			return err
+		}
+		if e.indent != "" {
+			if err := e.writeRaw(" "); err != nil {
+				return err
+			}
+		}
+		if err := e.encodeReflect(rv.MapIndex(k), depth+1); err != nil {
+			return err
+		}
+	}
+	if len(keys) > 0 {
+		if err := e.newline(depth); err != nil {
+			return err
+		}
+	}
+	return e.writeRaw("}")
+}
+
+func (e *Encoder) encodeStruct(rv reflect.Value, depth int) error {
+	t := rv.Type()
+	if err := e.writeRaw("{"); err != nil {
+		return err
+	}
+	wrote := 0
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := parseFieldTag(sf)
+		if tag.skip {
+			continue
+		}
+		fv := rv.Field(i)
+		if tag.omitempty && isEmptyValue(fv) {
+			continue
+		}
+
+		if wrote > 0 {
+			if err := e.writeRaw(","); err != nil {
+				return err
+			}
+		}
+		if err := e.newline(depth + 1); err != nil {
+			return err
+		}
+		if err := e.writeString(tag.name); err != nil {
+			return err
+		}
+		if err := e.writeRaw(":"); err != nil {
+			return err
+		}
+		if e.indent != "" {
+			if err := e.writeRaw(" "); err != nil {
+				return err
+			}
+		}
+		if err := e.encodeReflect(fv, depth+1); err != nil {
+			return err
+		}
+		wrote++
+	}
+	if wrote > 0 {
+		if err := e.newline(depth); err != nil {
+			return err
+		}
+	}
+	return e.writeRaw("}")
+}
+
+// encodeLorcValue encodes a lorc.Value AST node, used both for values
+// produced by Parse and for the output of Marshaler implementations.
+func (e *Encoder) encodeLorcValue(v Value, depth int) error {
+	switch val := v.(type) {
+	case nil:
+		return e.writeRaw("null")
+	case Null:
+		return e.writeRaw("null")
+	case Bool:
+		return e.writeRaw(strconv.FormatBool(bool(val)))
+	case String:
+		return e.writeString(string(val))
+	case Number:
+		return e.writeRaw(string(val))
+	case *Array:
+		if err := e.writeRaw("["); err != nil {
+			return err
+		}
+		for i, elem := range val.Elements {
+			if i > 0 {
+				if err := e.writeRaw(","); err != nil {
+					return err
+				}
+			}
+			if err := e.newline(depth + 1); err != nil {
+				return err
+			}
+			if err := e.encodeLorcValue(elem, depth+1); err != nil {
+				return err
+			}
+		}
+		if len(val.Elements) > 0 {
+			if err := e.newline(depth); err != nil {
+				return err
+			}
+		}
+		return e.writeRaw("]")
+	case *Object:
+		if err := e.writeRaw("{"); err != nil {
+			return err
+		}
+		for i, entry := range val.Entries() {
+			if i > 0 {
+				if err := e.writeRaw(","); err != nil {
+					return err
+				}
+			}
+			if err := e.newline(depth + 1); err != nil {
+				return err
+			}
+			if err := e.writeString(entry.Key); err != nil {
+				return err
+			}
+			if err := e.writeRaw(":"); err != nil {
+				return err
+			}
+			if e.indent != "" {
+				if err := e.writeRaw(" "); err != nil {
+					return err
+				}
+			}
+			if err := e.encodeLorcValue(entry.Value, depth+1); err != nil {
+				return err
+			}
+		}
+		if val.Len() > 0 {
+			if err := e.newline(depth); err != nil {
+				return err
+			}
+		}
+		return e.writeRaw("}")
+	default:
+		return fmt.Errorf("lorc: unsupported value type %T", v)
+	}
+}
+
+func (e *Encoder) writeRaw(s string) error {
+	_, err := e.w.WriteString(s)
+	return err
+}
+
+func (e *Encoder) writeString(s string) error {
+	if err := e.w.WriteByte('"'); err != nil {
+		return err
+	}
+	for _, r := range s {
+		switch {
+		case r == '"':
+			_, err := e.w.WriteString(`\"`)
+			if err != nil {
+				return err
+			}
+		case r == '\\':
+			if _, err := e.w.WriteString(`\\`); err != nil {
+				return err
+			}
+		case r == '\n':
+			if _, err := e.w.WriteString(`\n`); err != nil {
+				return err
+			}
+		case r == '\r':
+			if _, err := e.w.WriteString(`\r`); err != nil {
+				return err
+			}
+		case r == '\t':
+			if _, err := e.w.WriteString(`\t`); err != nil {
+				return err
+			}
+		case r < 0x20:
+			if _, err := fmt.Fprintf(e.w, `\u%04x`, r); err != nil {
+				return err
+			}
+		case e.escapeHTML && (r == '<' || r == '>' || r == '&' || r == ' ' || r == ' '):
+			if _, err := fmt.Fprintf(e.w, `\u%04x`, r); err != nil {
+				return err
+			}
+		default:
+			if _, err := e.w.WriteRune(r); err != nil {
+				return err
+			}
+		}
+	}
+	return e.w.WriteByte('"')
+}
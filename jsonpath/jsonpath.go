@@ -0,0 +1,444 @@
+// Package jsonpath compiles JSONPath expressions and evaluates them
+// against a lorc.Value AST.
+package jsonpath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Marbh56/Lorc"
+)
+
+// Match pairs a value found by a Path with the JSON pointer (RFC 6901
+// style, e.g. "/store/book/0/author") locating it in the source
+// document.
+type Match struct {
+	Value   lorc.Value
+	Pointer string
+}
+
+// Path is a compiled JSONPath expression, ready to be evaluated
+// against any number of documents.
+type Path struct {
+	steps []step
+}
+
+// Compile parses expr (e.g. "$.store.book[*].author", "$..price",
+// "$[?(@.price<10)]", "$.store.book[1:3]") into a reusable Path.
+func Compile(expr string) (*Path, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("jsonpath: expression must start with '$'")
+	}
+	steps, rest, err := parseSteps(expr[1:])
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("jsonpath: unexpected trailing input %q", rest)
+	}
+	return &Path{steps: steps}, nil
+}
+
+// Evaluate walks root and returns every value the path matches,
+// together with its pointer.
+func (p *Path) Evaluate(root lorc.Value) ([]Match, error) {
+	candidates := []Match{{Value: root, Pointer: ""}}
+	for _, st := range p.steps {
+		next, err := st.apply(candidates)
+		if err != nil {
+			return nil, err
+		}
+		candidates = next
+	}
+	return candidates, nil
+}
+
+// step is one operator in a compiled path: a name lookup, a wildcard,
+// a recursive descent, an index, a slice, or a filter.
+type step interface {
+	apply(in []Match) ([]Match, error)
+}
+
+// parseSteps tokenizes and parses the remainder of a path expression
+// (everything after the leading '$'), returning the compiled steps
+// and whatever input is left unconsumed.
+func parseSteps(rest string) ([]step, string, error) {
+	var steps []step
+	for len(rest) > 0 {
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			steps = append(steps, recursiveStep{})
+			rest = rest[2:]
+			if rest == "" || rest[0] == '.' {
+				return nil, "", fmt.Errorf("jsonpath: '..' must be followed by a name, '*', or '['")
+			}
+			if rest[0] == '[' {
+				continue
+			}
+			name, remainder := readName(rest)
+			steps = append(steps, nameOrWildcard(name))
+			rest = remainder
+
+		case rest[0] == '.':
+			rest = rest[1:]
+			name, remainder := readName(rest)
+			if name == "" {
+				return nil, "", fmt.Errorf("jsonpath: expected name after '.'")
+			}
+			steps = append(steps, nameOrWildcard(name))
+			rest = remainder
+
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, "", fmt.Errorf("jsonpath: unterminated '['")
+			}
+			content := rest[1:end]
+			st, err := parseBracket(content)
+			if err != nil {
+				return nil, "", err
+			}
+			steps = append(steps, st)
+			rest = rest[end+1:]
+
+		default:
+			return steps, rest, nil
+		}
+	}
+	return steps, rest, nil
+}
+
+func nameOrWildcard(name string) step {
+	if name == "*" {
+		return wildcardStep{}
+	}
+	return nameStep{name: name}
+}
+
+// readName reads a bare (unquoted) identifier up to the next '.' or
+// '[', or the end of the string.
+func readName(s string) (name, rest string) {
+	i := 0
+	for i < len(s) && s[i] != '.' && s[i] != '[' {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// parseBracket compiles the contents of a single [...] segment: a
+// wildcard, a quoted name, a filter predicate, a slice, or an index.
+func parseBracket(content string) (step, error) {
+	switch {
+	case content == "*":
+		return wildcardStep{}, nil
+
+	case strings.HasPrefix(content, "?(") && strings.HasSuffix(content, ")"):
+		return parseFilter(content[2 : len(content)-1])
+
+	case (strings.HasPrefix(content, "'") && strings.HasSuffix(content, "'")) ||
+		(strings.HasPrefix(content, `"`) && strings.HasSuffix(content, `"`)):
+		return nameStep{name: content[1 : len(content)-1]}, nil
+
+	case strings.Contains(content, ":"):
+		return parseSlice(content)
+
+	default:
+		idx, err := strconv.Atoi(strings.TrimSpace(content))
+		if err != nil {
+			return nil, fmt.Errorf("jsonpath: invalid index %q", content)
+		}
+		return indexStep{index: idx}, nil
+	}
+}
+
+func parseSlice(content string) (step, error) {
+	parts := strings.SplitN(content, ":", 2)
+	st := sliceStep{}
+	if s := strings.TrimSpace(parts[0]); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("jsonpath: invalid slice start %q", parts[0])
+		}
+		st.start, st.hasStart = v, true
+	}
+	if s := strings.TrimSpace(parts[1]); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("jsonpath: invalid slice end %q", parts[1])
+		}
+		st.end, st.hasEnd = v, true
+	}
+	return st, nil
+}
+
+// nameStep looks up a single object key.
+type nameStep struct {
+	name string
+}
+
+func (s nameStep) apply(in []Match) ([]Match, error) {
+	var out []Match
+	for _, m := range in {
+		obj, ok := m.Value.(*lorc.Object)
+		if !ok {
+			continue
+		}
+		if v, ok := obj.Get(s.name); ok {
+			out = append(out, Match{Value: v, Pointer: m.Pointer + "/" + escapePointerToken(s.name)})
+		}
+	}
+	return out, nil
+}
+
+// wildcardStep yields every child of an object or array.
+type wildcardStep struct{}
+
+func (wildcardStep) apply(in []Match) ([]Match, error) {
+	var out []Match
+	for _, m := range in {
+		out = append(out, children(m)...)
+	}
+	return out, nil
+}
+
+// recursiveStep collects every descendant of each candidate (including
+// the candidate itself) so that the following step can be applied
+// against all of them.
+type recursiveStep struct{}
+
+func (recursiveStep) apply(in []Match) ([]Match, error) {
+	var out []Match
+	for _, m := range in {
+		out = append(out, descendants(m)...)
+	}
+	return out, nil
+}
+
+func descendants(m Match) []Match {
+	out := []Match{m}
+	for _, c := range children(m) {
+		out = append(out, descendants(c)...)
+	}
+	return out
+}
+
+func children(m Match) []Match {
+	switch v := m.Value.(type) {
+	case *lorc.Object:
+		out := make([]Match, 0, v.Len())
+		for _, e := range v.Entries() {
+			out = append(out, Match{Value: e.Value, Pointer: m.Pointer + "/" + escapePointerToken(e.Key)})
+		}
+		return out
+	case *lorc.Array:
+		out := make([]Match, 0, len(v.Elements))
+		for i, e := range v.Elements {
+			out = append(out, Match{Value: e, Pointer: fmt.Sprintf("%s/%d", m.Pointer, i)})
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// indexStep selects a single array element by position. Negative
+// indices count back from the end of the array.
+type indexStep struct {
+	index int
+}
+
+func (s indexStep) apply(in []Match) ([]Match, error) {
+	var out []Match
+	for _, m := range in {
+		arr, ok := m.Value.(*lorc.Array)
+		if !ok {
+			continue
+		}
+		idx := s.index
+		if idx < 0 {
+			idx += len(arr.Elements)
+		}
+		if idx < 0 || idx >= len(arr.Elements) {
+			continue
+		}
+		out = append(out, Match{Value: arr.Elements[idx], Pointer: fmt.Sprintf("%s/%d", m.Pointer, idx)})
+	}
+	return out, nil
+}
+
+// sliceStep selects a Python-style [start:end] range of array
+// elements.
+type sliceStep struct {
+	start, end       int
+	hasStart, hasEnd bool
+}
+
+func (s sliceStep) apply(in []Match) ([]Match, error) {
+	var out []Match
+	for _, m := range in {
+		arr, ok := m.Value.(*lorc.Array)
+		if !ok {
+			continue
+		}
+		n := len(arr.Elements)
+		start, end := 0, n
+		if s.hasStart {
+			start = normalizeIndex(s.start, n)
+		}
+		if s.hasEnd {
+			end = normalizeIndex(s.end, n)
+		}
+		if start < 0 {
+			start = 0
+		}
+		if end > n {
+			end = n
+		}
+		for i := start; i < end; i++ {
+			out = append(out, Match{Value: arr.Elements[i], Pointer: fmt.Sprintf("%s/%d", m.Pointer, i)})
+		}
+	}
+	return out, nil
+}
+
+func normalizeIndex(i, n int) int {
+	if i < 0 {
+		return i + n
+	}
+	return i
+}
+
+// filterStep keeps the elements of an array (or the values of an
+// object) for which "@.field op literal" holds.
+type filterStep struct {
+	field string
+	op    string
+	value lorc.Value
+}
+
+var filterOps = []string{"<=", ">=", "==", "!=", "<", ">"}
+
+func parseFilter(expr string) (step, error) {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, "@.") {
+		return nil, fmt.Errorf("jsonpath: filter must start with '@.', got %q", expr)
+	}
+	expr = expr[2:]
+
+	for _, op := range filterOps {
+		if i := strings.Index(expr, op); i >= 0 {
+			field := strings.TrimSpace(expr[:i])
+			literal := strings.TrimSpace(expr[i+len(op):])
+			val, err := parseLiteral(literal)
+			if err != nil {
+				return nil, err
+			}
+			return filterStep{field: field, op: op, value: val}, nil
+		}
+	}
+	return nil, fmt.Errorf("jsonpath: filter %q has no recognized operator", expr)
+}
+
+func parseLiteral(s string) (lorc.Value, error) {
+	switch {
+	case s == "true":
+		return lorc.Bool(true), nil
+	case s == "false":
+		return lorc.Bool(false), nil
+	case s == "null":
+		return lorc.Null{}, nil
+	case len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0]:
+		return lorc.String(s[1 : len(s)-1]), nil
+	default:
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return nil, fmt.Errorf("jsonpath: invalid filter literal %q", s)
+		}
+		return lorc.Number(s), nil
+	}
+}
+
+func (s filterStep) apply(in []Match) ([]Match, error) {
+	var out []Match
+	for _, m := range in {
+		for _, c := range children(m) {
+			ok, err := s.matches(c.Value)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				out = append(out, c)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (s filterStep) matches(v lorc.Value) (bool, error) {
+	obj, ok := v.(*lorc.Object)
+	if !ok {
+		return false, nil
+	}
+	field, ok := obj.Get(s.field)
+	if !ok {
+		return false, nil
+	}
+	return compare(field, s.op, s.value)
+}
+
+func compare(a lorc.Value, op string, b lorc.Value) (bool, error) {
+	an, aIsNum := a.(lorc.Number)
+	bn, bIsNum := b.(lorc.Number)
+	if aIsNum && bIsNum {
+		af, err := an.Float64()
+		if err != nil {
+			return false, err
+		}
+		bf, err := bn.Float64()
+		if err != nil {
+			return false, err
+		}
+		switch op {
+		case "<":
+			return af < bf, nil
+		case "<=":
+			return af <= bf, nil
+		case ">":
+			return af > bf, nil
+		case ">=":
+			return af >= bf, nil
+		case "==":
+			return af == bf, nil
+		case "!=":
+			return af != bf, nil
+		}
+	}
+
+	as, aIsStr := a.(lorc.String)
+	bs, bIsStr := b.(lorc.String)
+	if aIsStr && bIsStr {
+		switch op {
+		case "==":
+			return as == bs, nil
+		case "!=":
+			return as != bs, nil
+		default:
+			return false, fmt.Errorf("jsonpath: operator %q not supported for strings", op)
+		}
+	}
+
+	switch op {
+	case "==":
+		return a == b, nil
+	case "!=":
+		return a != b, nil
+	default:
+		return false, fmt.Errorf("jsonpath: operator %q not supported between %T and %T", op, a, b)
+	}
+}
+
+func escapePointerToken(s string) string {
+	s = strings.ReplaceAll(s, "~", "~0")
+	s = strings.ReplaceAll(s, "/", "~1")
+	return s
+}
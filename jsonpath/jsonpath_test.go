@@ -0,0 +1,126 @@
+package jsonpath
+
+import (
+	"strings"
+	"testing"
+
+	lorc "github.com/Marbh56/Lorc"
+)
+
+const storeDoc = `{
+	"store": {
+		"book": [
+			{"author": "Herbert", "price": 12.5},
+			{"author": "Tolkien", "price": 7.99},
+			{"author": "Asimov", "price": 8.5}
+		]
+	}
+}`
+
+func parse(t *testing.T, doc string) lorc.Value {
+	t.Helper()
+	v, err := lorc.Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return v
+}
+
+func evalStrings(t *testing.T, expr, doc string) []string {
+	t.Helper()
+	p, err := Compile(expr)
+	if err != nil {
+		t.Fatalf("Compile(%q): %v", expr, err)
+	}
+	matches, err := p.Evaluate(parse(t, doc))
+	if err != nil {
+		t.Fatalf("Evaluate(%q): %v", expr, err)
+	}
+	out := make([]string, len(matches))
+	for i, m := range matches {
+		s, ok := m.Value.(lorc.String)
+		if !ok {
+			t.Fatalf("match %d is %T, want lorc.String", i, m.Value)
+		}
+		out[i] = string(s)
+	}
+	return out
+}
+
+func TestWildcardStep(t *testing.T) {
+	got := evalStrings(t, "$.store.book[*].author", storeDoc)
+	want := []string{"Herbert", "Tolkien", "Asimov"}
+	if !equalStrings(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRecursiveDescent(t *testing.T) {
+	p, err := Compile("$..price")
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	matches, err := p.Evaluate(parse(t, storeDoc))
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if len(matches) != 3 {
+		t.Fatalf("len(matches) = %d, want 3", len(matches))
+	}
+	for _, m := range matches {
+		if _, ok := m.Value.(lorc.Number); !ok {
+			t.Errorf("match %#v is not a Number", m.Value)
+		}
+	}
+}
+
+func TestFilterStep(t *testing.T) {
+	got := evalStrings(t, "$.store.book[?(@.price<10)].author", storeDoc)
+	want := []string{"Tolkien", "Asimov"}
+	if !equalStrings(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSliceStep(t *testing.T) {
+	got := evalStrings(t, "$.store.book[1:3].author", storeDoc)
+	want := []string{"Tolkien", "Asimov"}
+	if !equalStrings(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestIndexStepAndPointer(t *testing.T) {
+	p, err := Compile("$.store.book[0].author")
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	matches, err := p.Evaluate(parse(t, storeDoc))
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("len(matches) = %d, want 1", len(matches))
+	}
+	if want := "/store/book/0/author"; matches[0].Pointer != want {
+		t.Errorf("Pointer = %q, want %q", matches[0].Pointer, want)
+	}
+}
+
+func TestCompileRejectsMissingDollar(t *testing.T) {
+	if _, err := Compile("store.book"); err == nil {
+		t.Errorf("expected error for path without leading '$'")
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
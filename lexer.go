@@ -0,0 +1,269 @@
+package lorc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+// snippetRadius is the number of bytes of context kept on each side
+// of an error's offset when building a LexerError's Snippet.
+const snippetRadius = 10
+
+// LexerError reports a lexical error together with its location in
+// the source. For the input "bad \q escape" its message is
+// lex error: invalid escape sequence: \q at line 1 col 7 near '"bad \q escape"'
+type LexerError struct {
+	Reason  string
+	Offset  int
+	Line    int
+	Col     int
+	Snippet string
+}
+
+func (e *LexerError) Error() string {
+	return fmt.Sprintf("lex error: %s at line %d col %d near '%s'", e.Reason, e.Line, e.Col, e.Snippet)
+}
+
+// Lexer turns a stream of bytes into a sequence of Tokens.
+type Lexer struct {
+	reader *bufio.Reader
+	char   rune
+	size   int
+	err    error
+
+	offset int
+	line   int
+	col    int
+
+	history []byte
+}
+
+// NewLexer returns a Lexer reading from input.
+func NewLexer(input io.Reader) *Lexer {
+	l := &Lexer{
+		reader: bufio.NewReader(input),
+		line:   1,
+		col:    1,
+	}
+	l.readChar()
+	return l
+}
+
+func (l *Lexer) readChar() {
+	if l.char != 0 {
+		l.offset += l.size
+		if l.char == '\n' {
+			l.line++
+			l.col = 1
+		} else {
+			l.col++
+		}
+	}
+
+	char, size, err := l.reader.ReadRune()
+	if err != nil {
+		l.char = 0
+		l.size = 0
+		l.err = err
+		return
+	}
+	l.char = char
+	l.size = size
+	l.appendHistory(char)
+}
+
+func (l *Lexer) appendHistory(r rune) {
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], r)
+	l.history = append(l.history, buf[:n]...)
+	if len(l.history) > snippetRadius {
+		l.history = l.history[len(l.history)-snippetRadius:]
+	}
+}
+
+// snippet returns the ~2*snippetRadius byte window of source text
+// around the lexer's current position.
+func (l *Lexer) snippet() string {
+	b := make([]byte, 0, 2*snippetRadius)
+	b = append(b, l.history...)
+	if peeked, _ := l.reader.Peek(snippetRadius); len(peeked) > 0 {
+		b = append(b, peeked...)
+	}
+	return string(b)
+}
+
+// errorf builds a LexerError at the lexer's current position.
+func (l *Lexer) errorf(format string, args ...interface{}) *LexerError {
+	return &LexerError{
+		Reason:  fmt.Sprintf(format, args...),
+		Offset:  l.offset,
+		Line:    l.line,
+		Col:     l.col,
+		Snippet: l.snippet(),
+	}
+}
+
+func (l *Lexer) skipWhitespace() {
+	for l.char == ' ' || l.char == '\t' || l.char == '\n' || l.char == '\r' {
+		l.readChar()
+	}
+}
+
+func (l *Lexer) readString() (string, *LexerError) {
+	var result []rune
+
+	l.readChar() // Skip the opening quote
+
+	for l.char != '"' && l.err == nil {
+		if l.char == '\\' {
+			l.readChar()
+			switch l.char {
+			case '"', '\\', '/':
+				result = append(result, l.char)
+			case 'b':
+				result = append(result, '\b')
+			case 'f':
+				result = append(result, '\f')
+			case 'n':
+				result = append(result, '\n')
+			case 'r':
+				result = append(result, '\r')
+			case 't':
+				result = append(result, '\t')
+			default:
+				return "", l.errorf("invalid escape sequence: \\%c", l.char)
+			}
+		} else if l.char < 32 {
+			return "", l.errorf("invalid control character in string: %d", l.char)
+		} else {
+			result = append(result, l.char)
+		}
+		l.readChar()
+	}
+
+	if l.err == io.EOF {
+		return "", l.errorf("unterminated string")
+	}
+
+	l.readChar() // Skip the closing quote
+	return string(result), nil
+}
+
+func (l *Lexer) readNumber() string {
+	var result []rune
+
+	// Handle negative numbers
+	if l.char == '-' {
+		result = append(result, l.char)
+		l.readChar()
+	}
+
+	// Read integer part
+	for l.err == nil && unicode.IsDigit(l.char) {
+		result = append(result, l.char)
+		l.readChar()
+	}
+
+	// Handle decimal point
+	if l.char == '.' {
+		result = append(result, l.char)
+		l.readChar()
+
+		// Read fractional part
+		for l.err == nil && unicode.IsDigit(l.char) {
+			result = append(result, l.char)
+			l.readChar()
+		}
+	}
+
+	// Handle exponent notation
+	if l.char == 'e' || l.char == 'E' {
+		result = append(result, l.char)
+		l.readChar()
+
+		// Handle exponent sign
+		if l.char == '+' || l.char == '-' {
+			result = append(result, l.char)
+			l.readChar()
+		}
+
+		// Read exponent digits
+		for l.err == nil && unicode.IsDigit(l.char) {
+			result = append(result, l.char)
+			l.readChar()
+		}
+	}
+
+	return string(result)
+}
+
+func (l *Lexer) readIdentifier() string {
+	var result []rune
+	for l.err == nil && (unicode.IsLetter(l.char) || l.char == '_') {
+		result = append(result, l.char)
+		l.readChar()
+	}
+	return string(result)
+}
+
+// NextToken consumes and returns the next Token in the stream.
+func (l *Lexer) NextToken() Token {
+	l.skipWhitespace()
+
+	startOffset, startLine, startCol := l.offset, l.line, l.col
+
+	if l.err == io.EOF {
+		return Token{Type: TokenEOF, StartOffset: startOffset, EndOffset: startOffset, Line: startLine, Col: startCol}
+	}
+
+	var tok Token
+
+	switch {
+	case l.char == '{':
+		tok = Token{Type: TokenLeftBrace, Literal: string(l.char)}
+		l.readChar()
+	case l.char == '}':
+		tok = Token{Type: TokenRightBrace, Literal: string(l.char)}
+		l.readChar()
+	case l.char == '[':
+		tok = Token{Type: TokenLeftBracket, Literal: string(l.char)}
+		l.readChar()
+	case l.char == ']':
+		tok = Token{Type: TokenRightBracket, Literal: string(l.char)}
+		l.readChar()
+	case l.char == ':':
+		tok = Token{Type: TokenColon, Literal: string(l.char)}
+		l.readChar()
+	case l.char == ',':
+		tok = Token{Type: TokenComma, Literal: string(l.char)}
+		l.readChar()
+	case l.char == '"':
+		if str, err := l.readString(); err != nil {
+			tok = Token{Type: TokenError, Err: err}
+		} else {
+			tok = Token{Type: TokenString, Literal: str}
+		}
+	case unicode.IsDigit(l.char) || l.char == '-':
+		number := l.readNumber()
+		tok = Token{Type: TokenNumber, Literal: number}
+	case unicode.IsLetter(l.char):
+		identifier := l.readIdentifier()
+		switch identifier {
+		case "true", "false":
+			tok = Token{Type: TokenBoolean, Literal: identifier}
+		case "null":
+			tok = Token{Type: TokenNull, Literal: identifier}
+		default:
+			tok = Token{Type: TokenError, Err: l.errorf("invalid identifier: %s", identifier)}
+		}
+	default:
+		tok = Token{Type: TokenError, Err: l.errorf("unexpected character: %q", l.char)}
+	}
+
+	tok.StartOffset, tok.EndOffset = startOffset, l.offset
+	tok.Line, tok.Col = startLine, startCol
+	return tok
+}
@@ -0,0 +1,77 @@
+package lorc
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLexerTracksLineAndCol(t *testing.T) {
+	l := NewLexer(strings.NewReader("{\n  \"a\": 1\n}"))
+
+	tok := l.NextToken() // '{'
+	if tok.Line != 1 || tok.Col != 1 {
+		t.Fatalf("'{' at line %d col %d, want 1 1", tok.Line, tok.Col)
+	}
+
+	tok = l.NextToken() // "a"
+	if tok.Line != 2 || tok.Col != 3 {
+		t.Fatalf(`"a" at line %d col %d, want 2 3`, tok.Line, tok.Col)
+	}
+
+	tok = l.NextToken() // ':'
+	if tok.Line != 2 || tok.Col != 6 {
+		t.Fatalf("':' at line %d col %d, want 2 6", tok.Line, tok.Col)
+	}
+}
+
+func TestLexerErrorMidString(t *testing.T) {
+	l := NewLexer(strings.NewReader(`"bad \q escape"`))
+	tok := l.NextToken()
+	if tok.Type != TokenError {
+		t.Fatalf("Type = %v, want TokenError", tok.Type)
+	}
+	var lexErr *LexerError
+	if !errors.As(tok.Err, &lexErr) {
+		t.Fatalf("Err = %v, want *LexerError", tok.Err)
+	}
+	if lexErr.Line != 1 {
+		t.Errorf("Line = %d, want 1", lexErr.Line)
+	}
+}
+
+func TestParseErrorInsideArray(t *testing.T) {
+	_, err := Parse(strings.NewReader(`[1, @]`))
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	var lexErr *LexerError
+	if !errors.As(err, &lexErr) {
+		t.Fatalf("err = %v (%T), want *LexerError", err, err)
+	}
+	if lexErr.Col != 5 {
+		t.Errorf("Col = %d, want 5", lexErr.Col)
+	}
+}
+
+func TestParseErrorInsideObject(t *testing.T) {
+	_, err := Parse(strings.NewReader(`{"a": 1 "b": 2}`))
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("err = %v (%T), want *ParseError", err, err)
+	}
+}
+
+func TestParseObjectSurfacesLexerErrorDirectly(t *testing.T) {
+	_, err := NewParser(NewLexer(strings.NewReader("@bad")), ParseOptions{}).ParseObject()
+	var lexErr *LexerError
+	if !errors.As(err, &lexErr) {
+		t.Fatalf("err = %v (%T), want *LexerError", err, err)
+	}
+	if lexErr.Reason != `unexpected character: '@'` {
+		t.Errorf("Reason = %q", lexErr.Reason)
+	}
+}
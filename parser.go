@@ -0,0 +1,271 @@
+package lorc
+
+import (
+	"fmt"
+	"io"
+)
+
+// ParseError reports a syntax error together with its location in
+// the source. For the input {"a": 1 "b": 2} its message is
+// parse error: expected ',' or '}', got 'b' at line 1 col 9 near 'a": 1 "b": 2}'
+type ParseError struct {
+	Reason  string
+	Offset  int
+	Line    int
+	Col     int
+	Snippet string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parse error: %s at line %d col %d near '%s'", e.Reason, e.Line, e.Col, e.Snippet)
+}
+
+// DuplicateKeys controls how a Parser handles an object with more
+// than one entry for the same key.
+type DuplicateKeys int
+
+const (
+	// DuplicateKeysMergeLast keeps only the last value seen for a
+	// repeated key, overwriting earlier ones in place. This is the
+	// zero value, matching historical behavior.
+	DuplicateKeysMergeLast DuplicateKeys = iota
+	// DuplicateKeysAllow keeps every entry, in source order, so
+	// Object.Entries and Object.Keys report the key more than once.
+	DuplicateKeysAllow
+	// DuplicateKeysReject fails parsing with a ParseError as soon as
+	// a repeated key is seen.
+	DuplicateKeysReject
+)
+
+// ParseOptions configures a Parser's tolerance for adversarial or
+// unusual input. The zero value matches the parser's historical,
+// permissive behavior: duplicate keys silently overwrite, and nesting
+// is unbounded.
+type ParseOptions struct {
+	DuplicateKeys DuplicateKeys
+	// MaxDepth bounds how deeply objects and arrays may nest. Zero
+	// means unlimited.
+	MaxDepth int
+}
+
+// Parse reads a single JSON value from r and returns its AST.
+func Parse(r io.Reader) (Value, error) {
+	return NewParser(NewLexer(r), ParseOptions{}).Parse()
+}
+
+// ParseWithOptions is like Parse but applies opts, e.g. to reject
+// duplicate object keys or bound recursion depth against adversarial
+// input.
+func ParseWithOptions(r io.Reader, opts ParseOptions) (Value, error) {
+	return NewParser(NewLexer(r), opts).Parse()
+}
+
+// Parser builds a Value tree from the tokens produced by a Lexer.
+type Parser struct {
+	lexer *Lexer
+	token Token
+	opts  ParseOptions
+	depth int
+}
+
+// NewParser returns a Parser reading tokens from lexer, governed by
+// opts.
+func NewParser(lexer *Lexer, opts ParseOptions) *Parser {
+	p := &Parser{lexer: lexer, opts: opts}
+	p.nextToken()
+	return p
+}
+
+func (p *Parser) nextToken() {
+	p.token = p.lexer.NextToken()
+}
+
+// errorf builds a ParseError positioned at the current token.
+func (p *Parser) errorf(format string, args ...interface{}) *ParseError {
+	return &ParseError{
+		Reason:  fmt.Sprintf(format, args...),
+		Offset:  p.token.StartOffset,
+		Line:    p.token.Line,
+		Col:     p.token.Col,
+		Snippet: p.lexer.snippet(),
+	}
+}
+
+// setKey records key/value into obj according to the parser's
+// DuplicateKeys policy.
+func (p *Parser) setKey(obj *Object, key string, value Value) error {
+	_, exists := obj.Get(key)
+	switch {
+	case exists && p.opts.DuplicateKeys == DuplicateKeysReject:
+		return p.errorf("duplicate key %q", key)
+	case exists && p.opts.DuplicateKeys == DuplicateKeysAllow:
+		obj.entries = append(obj.entries, Entry{Key: key, Value: value})
+	default:
+		obj.Set(key, value)
+	}
+	return nil
+}
+
+// enterDepth accounts for one more level of object/array nesting,
+// failing if MaxDepth has been reached.
+func (p *Parser) enterDepth() error {
+	if p.opts.MaxDepth > 0 && p.depth >= p.opts.MaxDepth {
+		return p.errorf("max nesting depth %d exceeded", p.opts.MaxDepth)
+	}
+	p.depth++
+	return nil
+}
+
+func (p *Parser) leaveDepth() {
+	p.depth--
+}
+
+// describe renders the current token for use in an error message.
+func (p *Parser) describe() string {
+	if p.token.Type == TokenEOF {
+		return "end of input"
+	}
+	return p.token.Literal
+}
+
+// Parse parses a single JSON value of any kind and returns its AST.
+func (p *Parser) Parse() (Value, error) {
+	return p.parseValue()
+}
+
+// parseValue parses any JSON value.
+func (p *Parser) parseValue() (Value, error) {
+	switch p.token.Type {
+	case TokenString:
+		v := String(p.token.Literal)
+		p.nextToken()
+		return v, nil
+	case TokenNumber:
+		v := Number(p.token.Literal)
+		p.nextToken()
+		return v, nil
+	case TokenBoolean:
+		v := Bool(p.token.Literal == "true")
+		p.nextToken()
+		return v, nil
+	case TokenNull:
+		p.nextToken()
+		return Null{}, nil
+	case TokenLeftBrace:
+		return p.ParseObject()
+	case TokenLeftBracket:
+		return p.parseArray()
+	case TokenError:
+		return nil, p.token.Err
+	default:
+		return nil, p.errorf("expected value, got '%s'", p.describe())
+	}
+}
+
+// parseArray parses a JSON array: []
+func (p *Parser) parseArray() (*Array, error) {
+	if err := p.enterDepth(); err != nil {
+		return nil, err
+	}
+	defer p.leaveDepth()
+
+	if p.token.Type == TokenError {
+		return nil, p.token.Err
+	}
+
+	// Move past the opening bracket
+	p.nextToken()
+
+	arr := &Array{}
+
+	// Handle empty array case
+	if p.token.Type == TokenRightBracket {
+		p.nextToken()
+		return arr, nil
+	}
+
+	// Parse values until we hit the closing bracket
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		arr.Elements = append(arr.Elements, value)
+
+		if p.token.Type == TokenRightBracket {
+			p.nextToken()
+			return arr, nil
+		}
+
+		if p.token.Type != TokenComma {
+			return nil, p.errorf("expected ',' or ']', got '%s'", p.describe())
+		}
+
+		p.nextToken()
+	}
+}
+
+// ParseObject parses a JSON object: {}
+func (p *Parser) ParseObject() (*Object, error) {
+	if err := p.enterDepth(); err != nil {
+		return nil, err
+	}
+	defer p.leaveDepth()
+
+	if p.token.Type == TokenError {
+		return nil, p.token.Err
+	}
+
+	// Expect opening brace
+	if p.token.Type != TokenLeftBrace {
+		return nil, p.errorf("expected '{', got '%s'", p.describe())
+	}
+	p.nextToken()
+
+	obj := &Object{}
+
+	// Handle empty object case
+	if p.token.Type == TokenRightBrace {
+		p.nextToken()
+		return obj, nil
+	}
+
+	// Parse key-value pairs
+	for {
+		// Parse key (must be string)
+		if p.token.Type == TokenError {
+			return nil, p.token.Err
+		}
+		if p.token.Type != TokenString {
+			return nil, p.errorf("expected string key, got '%s'", p.describe())
+		}
+		key := p.token.Literal
+		p.nextToken()
+
+		// Expect colon
+		if p.token.Type != TokenColon {
+			return nil, p.errorf("expected ':', got '%s'", p.describe())
+		}
+		p.nextToken()
+
+		// Parse value
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.setKey(obj, key, value); err != nil {
+			return nil, err
+		}
+
+		// After a key-value pair, expect either comma or closing brace
+		if p.token.Type == TokenRightBrace {
+			p.nextToken()
+			return obj, nil
+		}
+
+		if p.token.Type != TokenComma {
+			return nil, p.errorf("expected ',' or '}', got '%s'", p.describe())
+		}
+		p.nextToken()
+	}
+}
@@ -0,0 +1,115 @@
+package lorc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseObjectPreservesOrder(t *testing.T) {
+	v, err := Parse(strings.NewReader(`{"b": 1, "a": 2, "c": 3}`))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	obj, ok := v.(*Object)
+	if !ok {
+		t.Fatalf("Parse returned %T, want *Object", v)
+	}
+	if got, want := obj.Keys(), []string{"b", "a", "c"}; !equalStrings(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+}
+
+func TestParseArrayAndScalars(t *testing.T) {
+	v, err := Parse(strings.NewReader(`[1, "two", true, null, 3.5]`))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	arr, ok := v.(*Array)
+	if !ok {
+		t.Fatalf("Parse returned %T, want *Array", v)
+	}
+	if len(arr.Elements) != 5 {
+		t.Fatalf("len(Elements) = %d, want 5", len(arr.Elements))
+	}
+	if n, ok := arr.Elements[0].(Number); !ok || n != "1" {
+		t.Errorf("Elements[0] = %#v, want Number(1)", arr.Elements[0])
+	}
+	if s, ok := arr.Elements[1].(String); !ok || s != "two" {
+		t.Errorf("Elements[1] = %#v, want String(two)", arr.Elements[1])
+	}
+	if b, ok := arr.Elements[2].(Bool); !ok || !bool(b) {
+		t.Errorf("Elements[2] = %#v, want Bool(true)", arr.Elements[2])
+	}
+	if _, ok := arr.Elements[3].(Null); !ok {
+		t.Errorf("Elements[3] = %#v, want Null", arr.Elements[3])
+	}
+}
+
+func TestParseWithOptionsDuplicateKeys(t *testing.T) {
+	input := `{"a": 1, "a": 2}`
+
+	v, err := ParseWithOptions(strings.NewReader(input), ParseOptions{DuplicateKeys: DuplicateKeysMergeLast})
+	if err != nil {
+		t.Fatalf("Parse (merge-last): %v", err)
+	}
+	obj := v.(*Object)
+	if obj.Len() != 1 {
+		t.Errorf("merge-last: Len() = %d, want 1", obj.Len())
+	}
+	if val, _ := obj.Get("a"); val.(Number) != "2" {
+		t.Errorf("merge-last: a = %v, want 2", val)
+	}
+
+	v, err = ParseWithOptions(strings.NewReader(input), ParseOptions{DuplicateKeys: DuplicateKeysAllow})
+	if err != nil {
+		t.Fatalf("Parse (allow): %v", err)
+	}
+	obj = v.(*Object)
+	if obj.Len() != 2 {
+		t.Errorf("allow: Len() = %d, want 2", obj.Len())
+	}
+
+	_, err = ParseWithOptions(strings.NewReader(input), ParseOptions{DuplicateKeys: DuplicateKeysReject})
+	if err == nil {
+		t.Errorf("reject: expected error, got nil")
+	}
+}
+
+func TestParseWithOptionsMaxDepth(t *testing.T) {
+	_, err := ParseWithOptions(strings.NewReader(`[[[1]]]`), ParseOptions{MaxDepth: 2})
+	if err == nil {
+		t.Fatalf("expected max depth error, got nil")
+	}
+
+	_, err = ParseWithOptions(strings.NewReader(`[[1]]`), ParseOptions{MaxDepth: 2})
+	if err != nil {
+		t.Fatalf("unexpected error within max depth: %v", err)
+	}
+}
+
+func TestObjectIndexStaysConsistentAfterOverwrite(t *testing.T) {
+	obj := &Object{}
+	obj.Set("a", Number("1"))
+	obj.Set("b", Number("2"))
+	obj.Set("a", Number("3"))
+
+	if got, want := obj.Keys(), []string{"a", "b"}; !equalStrings(got, want) {
+		t.Fatalf("Keys() = %v, want %v", got, want)
+	}
+	v, ok := obj.Get("a")
+	if !ok || v.(Number) != "3" {
+		t.Errorf("Get(a) = %v, %v, want 3, true", v, ok)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
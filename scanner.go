@@ -0,0 +1,293 @@
+package lorc
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Kind identifies the category of value a Scanner is positioned at.
+type Kind byte
+
+const (
+	KindDelim Kind = iota
+	KindString
+	KindNumber
+	KindBool
+	KindNull
+	KindEOF
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindDelim:
+		return "delim"
+	case KindString:
+		return "string"
+	case KindNumber:
+		return "number"
+	case KindBool:
+		return "bool"
+	case KindNull:
+		return "null"
+	case KindEOF:
+		return "eof"
+	default:
+		return "unknown"
+	}
+}
+
+// Scanner pulls tokens from a Lexer one at a time without building an
+// AST, so callers can walk very large documents with bounded memory.
+// A typical top-level array is consumed with Peek, ConsumeDelim('['),
+// then a loop of Skip or the typed accessors per element.
+type Scanner struct {
+	lexer  *Lexer
+	peeked *Token
+	err    error
+}
+
+// NewScanner returns a Scanner reading tokens from lexer.
+func NewScanner(lexer *Lexer) *Scanner {
+	return &Scanner{lexer: lexer}
+}
+
+// Err returns the first lexical error met by Int64, Uint64, or
+// Float64, or nil; the other methods return their lexical errors
+// directly and do not record them here. Int64, Uint64, and Float64 report a plain
+// ok=false both when the next value isn't a number and when the
+// lexer itself failed; callers that want to tell those cases apart
+// (rather than treat ok=false as "skip this field") should check Err
+// after a false result.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+func (s *Scanner) recordErr(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+func (s *Scanner) peek() Token {
+	if s.peeked == nil {
+		tok := s.lexer.NextToken()
+		s.peeked = &tok
+	}
+	return *s.peeked
+}
+
+func (s *Scanner) advance() Token {
+	tok := s.peek()
+	s.peeked = nil
+	return tok
+}
+
+func kindOf(tok Token) Kind {
+	switch tok.Type {
+	case TokenLeftBrace, TokenRightBrace, TokenLeftBracket, TokenRightBracket, TokenColon, TokenComma:
+		return KindDelim
+	case TokenString:
+		return KindString
+	case TokenNumber:
+		return KindNumber
+	case TokenBoolean:
+		return KindBool
+	case TokenNull:
+		return KindNull
+	default:
+		return KindEOF
+	}
+}
+
+// Peek reports the Kind of the next value without consuming it.
+func (s *Scanner) Peek() (Kind, error) {
+	tok := s.peek()
+	if tok.Type == TokenError {
+		return KindEOF, tok.Err
+	}
+	return kindOf(tok), nil
+}
+
+// Delim reports the literal delimiter byte ('{', '}', '[', ']', ':',
+// or ',') the scanner is positioned at.
+func (s *Scanner) Delim() (byte, error) {
+	tok := s.peek()
+	if tok.Type == TokenError {
+		return 0, tok.Err
+	}
+	if kindOf(tok) != KindDelim {
+		return 0, fmt.Errorf("scanner: expected delimiter, got %s", kindOf(tok))
+	}
+	return tok.Literal[0], nil
+}
+
+// Consume checks that the next value is of the given kind and
+// advances past it.
+func (s *Scanner) Consume(kind Kind) error {
+	tok := s.peek()
+	if tok.Type == TokenError {
+		return tok.Err
+	}
+	if got := kindOf(tok); got != kind {
+		return fmt.Errorf("scanner: expected %s, got %s", kind, got)
+	}
+	s.advance()
+	return nil
+}
+
+// ConsumeDelim checks that the next token is exactly the given
+// delimiter byte and advances past it.
+func (s *Scanner) ConsumeDelim(b byte) error {
+	got, err := s.Delim()
+	if err != nil {
+		return err
+	}
+	if got != b {
+		return fmt.Errorf("scanner: expected delimiter '%c', got '%c'", b, got)
+	}
+	s.advance()
+	return nil
+}
+
+// String consumes the next token and returns it as a string.
+func (s *Scanner) String() (string, error) {
+	tok := s.peek()
+	if tok.Type == TokenError {
+		return "", tok.Err
+	}
+	if tok.Type != TokenString {
+		return "", fmt.Errorf("scanner: expected string, got %s", kindOf(tok))
+	}
+	s.advance()
+	return tok.Literal, nil
+}
+
+// Bool consumes the next token and returns it as a bool.
+func (s *Scanner) Bool() (bool, error) {
+	tok := s.peek()
+	if tok.Type == TokenError {
+		return false, tok.Err
+	}
+	if tok.Type != TokenBoolean {
+		return false, fmt.Errorf("scanner: expected bool, got %s", kindOf(tok))
+	}
+	s.advance()
+	return tok.Literal == "true", nil
+}
+
+// Int64 consumes the next token and parses it as an int64. It reports
+// false if the token isn't a number, or if it overflows int64.
+func (s *Scanner) Int64() (int64, bool) {
+	tok := s.peek()
+	if tok.Type == TokenError {
+		s.recordErr(tok.Err)
+		return 0, false
+	}
+	if tok.Type != TokenNumber {
+		return 0, false
+	}
+	s.advance()
+	n, err := strconv.ParseInt(tok.Literal, 10, 64)
+	return n, err == nil
+}
+
+// Uint64 consumes the next token and parses it as a uint64. It
+// reports false if the token isn't a number, or if it overflows
+// uint64.
+func (s *Scanner) Uint64() (uint64, bool) {
+	tok := s.peek()
+	if tok.Type == TokenError {
+		s.recordErr(tok.Err)
+		return 0, false
+	}
+	if tok.Type != TokenNumber {
+		return 0, false
+	}
+	s.advance()
+	n, err := strconv.ParseUint(tok.Literal, 10, 64)
+	return n, err == nil
+}
+
+// Float64 consumes the next token and parses it as a float64. It
+// reports false if the token isn't a number, or if it overflows
+// float64.
+func (s *Scanner) Float64() (float64, bool) {
+	tok := s.peek()
+	if tok.Type == TokenError {
+		s.recordErr(tok.Err)
+		return 0, false
+	}
+	if tok.Type != TokenNumber {
+		return 0, false
+	}
+	s.advance()
+	n, err := strconv.ParseFloat(tok.Literal, 64)
+	return n, err == nil
+}
+
+// Skip recursively skips the next value without building an AST.
+func (s *Scanner) Skip() error {
+	tok := s.advance()
+	switch tok.Type {
+	case TokenError:
+		return tok.Err
+	case TokenString, TokenNumber, TokenBoolean, TokenNull:
+		return nil
+	case TokenLeftBrace:
+		return s.skipObject()
+	case TokenLeftBracket:
+		return s.skipArray()
+	default:
+		return fmt.Errorf("scanner: expected value, got %s", kindOf(tok))
+	}
+}
+
+func (s *Scanner) skipObject() error {
+	if s.peek().Type == TokenRightBrace {
+		s.advance()
+		return nil
+	}
+	for {
+		if err := s.Consume(KindString); err != nil {
+			return err
+		}
+		if err := s.ConsumeDelim(':'); err != nil {
+			return err
+		}
+		if err := s.Skip(); err != nil {
+			return err
+		}
+		switch tok := s.advance(); tok.Type {
+		case TokenRightBrace:
+			return nil
+		case TokenComma:
+			continue
+		case TokenError:
+			return tok.Err
+		default:
+			return fmt.Errorf("scanner: expected ',' or '}', got %s", kindOf(tok))
+		}
+	}
+}
+
+func (s *Scanner) skipArray() error {
+	if s.peek().Type == TokenRightBracket {
+		s.advance()
+		return nil
+	}
+	for {
+		if err := s.Skip(); err != nil {
+			return err
+		}
+		switch tok := s.advance(); tok.Type {
+		case TokenRightBracket:
+			return nil
+		case TokenComma:
+			continue
+		case TokenError:
+			return tok.Err
+		default:
+			return fmt.Errorf("scanner: expected ',' or ']', got %s", kindOf(tok))
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package lorc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScannerSkipsTopLevelArrayRecords(t *testing.T) {
+	s := NewScanner(NewLexer(strings.NewReader(`[{"a":1,"b":[1,2,3]}, "x", 42, null]`)))
+
+	if kind, err := s.Peek(); err != nil || kind != KindDelim {
+		t.Fatalf("Peek() = %v, %v, want KindDelim, nil", kind, err)
+	}
+	if err := s.ConsumeDelim('['); err != nil {
+		t.Fatalf("ConsumeDelim('['): %v", err)
+	}
+
+	count := 0
+	for {
+		if d, err := s.Delim(); err == nil && d == ']' {
+			s.advance()
+			break
+		}
+		if err := s.Skip(); err != nil {
+			t.Fatalf("Skip: %v", err)
+		}
+		count++
+		if d, err := s.Delim(); err == nil && d == ',' {
+			s.advance()
+		}
+	}
+	if count != 4 {
+		t.Errorf("count = %d, want 4", count)
+	}
+}
+
+func TestScannerTypedAccessors(t *testing.T) {
+	s := NewScanner(NewLexer(strings.NewReader(`"hi" true 42 3.5 18446744073709551615`)))
+
+	str, err := s.String()
+	if err != nil || str != "hi" {
+		t.Fatalf("String() = %q, %v", str, err)
+	}
+	b, err := s.Bool()
+	if err != nil || !b {
+		t.Fatalf("Bool() = %v, %v", b, err)
+	}
+	i, ok := s.Int64()
+	if !ok || i != 42 {
+		t.Fatalf("Int64() = %d, %v", i, ok)
+	}
+	f, ok := s.Float64()
+	if !ok || f != 3.5 {
+		t.Fatalf("Float64() = %v, %v", f, ok)
+	}
+	u, ok := s.Uint64()
+	if !ok || u != 18446744073709551615 {
+		t.Fatalf("Uint64() = %d, %v", u, ok)
+	}
+}
+
+func TestScannerIntOverflow(t *testing.T) {
+	s := NewScanner(NewLexer(strings.NewReader(`99999999999999999999`)))
+	_, ok := s.Int64()
+	if ok {
+		t.Errorf("Int64() overflow: ok = true, want false")
+	}
+}
+
+func TestScannerNumberAccessorSurfacesLexErrorViaErr(t *testing.T) {
+	s := NewScanner(NewLexer(strings.NewReader(`@bad`)))
+	_, ok := s.Int64()
+	if ok {
+		t.Fatalf("Int64() = true, want false on lex error")
+	}
+	if s.Err() == nil {
+		t.Fatalf("Err() = nil, want the underlying lex error")
+	}
+}
+
+func TestScannerConsumeWrongKindError(t *testing.T) {
+	s := NewScanner(NewLexer(strings.NewReader(`"str"`)))
+	if err := s.Consume(KindBool); err == nil {
+		t.Errorf("Consume(KindBool) on a string: expected error, got nil")
+	}
+}
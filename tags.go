@@ -0,0 +1,59 @@
+package lorc
+
+import (
+	"reflect"
+	"strings"
+)
+
+// fieldTag is the parsed `json:"name,omitempty"` tag for a struct
+// field.
+type fieldTag struct {
+	name      string
+	omitempty bool
+	skip      bool
+}
+
+// parseFieldTag reads sf's json tag, falling back to the Go field
+// name when no tag (or no name component) is present.
+func parseFieldTag(sf reflect.StructField) fieldTag {
+	raw, ok := sf.Tag.Lookup("json")
+	if !ok {
+		return fieldTag{name: sf.Name}
+	}
+	name, opts, _ := strings.Cut(raw, ",")
+	if name == "-" && opts == "" {
+		return fieldTag{skip: true}
+	}
+	if name == "" {
+		name = sf.Name
+	}
+	ft := fieldTag{name: name}
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == "omitempty" {
+			ft.omitempty = true
+		}
+	}
+	return ft
+}
+
+// isEmptyValue reports whether v is the zero value for its type, per
+// the rules the "omitempty" tag option uses.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
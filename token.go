@@ -0,0 +1,40 @@
+package lorc
+
+// TokenType identifies the lexical class of a Token.
+type TokenType int
+
+const (
+	TokenError TokenType = iota
+	TokenLeftBrace
+	TokenRightBrace
+	TokenLeftBracket
+	TokenRightBracket
+	TokenString
+	TokenNumber
+	TokenBoolean
+	TokenNull
+	TokenColon
+	TokenComma
+	TokenEOF
+)
+
+// Token is a single lexical token produced by the Lexer, with the
+// source span it came from.
+type Token struct {
+	Type    TokenType
+	Literal string
+
+	// StartOffset and EndOffset are byte offsets into the source,
+	// spanning [StartOffset, EndOffset).
+	StartOffset int
+	EndOffset   int
+
+	// Line and Col are the 1-based line and column of the token's
+	// first byte.
+	Line int
+	Col  int
+
+	// Err holds the structured lexical error when Type is
+	// TokenError.
+	Err *LexerError
+}